@@ -0,0 +1,31 @@
+package p2p
+
+import (
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// EncodingConfig reports the wire encoding a Service uses for gossip and RPC, so callers can
+// build topic and protocol strings without depending on the concrete encoding in use.
+type EncodingConfig interface {
+	// ProtocolSuffix returns the string appended to a topic or protocol ID to identify this
+	// encoding, e.g. "/ssz_snappy".
+	ProtocolSuffix() string
+}
+
+// P2P is the interface the rest of the beacon chain depends on to interact with the libp2p
+// networking stack, satisfied by *Service. It exists so packages like sync can depend on a
+// narrow interface instead of the concrete Service and its full construction dependencies.
+type P2P interface {
+	// Encoding returns the wire encoding this service is configured to use.
+	Encoding() EncodingConfig
+	// PubSub returns the underlying GossipSub router.
+	PubSub() *pubsub.PubSub
+	// RefreshSubnetPeers checks peer inventory for the given attestation subnets and launches a
+	// directed discovery search for any subnet under its target peer count.
+	RefreshSubnetPeers(indices []uint64, peerCount func(subnetIndex uint64) int)
+	// SubnetPeerCount reports how many peers are currently known, via a directed discovery
+	// search, to advertise the given attestation subnet in their ENR.
+	SubnetPeerCount(subnetIndex uint64) int
+}
+
+var _ P2P = (*Service)(nil)
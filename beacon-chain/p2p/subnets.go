@@ -0,0 +1,185 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// attSubnetEnrKey is the ENR entry key under which a node advertises the attestation subnets
+// it is currently subscribed to, as a 64-bit bitfield.
+const attSubnetEnrKey = "attnets"
+
+// MinPeersPerSubnet is the number of peers the service tries to maintain on each attestation
+// subnet it is aggregating on before it stops actively searching for more.
+const MinPeersPerSubnet = 6
+
+// subnetSearchTimeout bounds how long a single directed discv5 walk for a given subnet may run
+// before giving up for that round.
+const subnetSearchTimeout = 30 * time.Second
+
+// nodeAdvertisesSubnet reports whether node's ENR record carries an attnets bitfield with the
+// bit for subnetIndex set. A node with no attnets entry, or a malformed one, does not match.
+func nodeAdvertisesSubnet(node *enode.Node, subnetIndex uint64) bool {
+	if node == nil {
+		return false
+	}
+	bitV := make(bitfield.Bitvector64, 8)
+	if err := node.Record().Load(enr.WithEntry(attSubnetEnrKey, &bitV)); err != nil {
+		return false
+	}
+	return subnetIndex < uint64(bitV.Len()) && bitV.BitAt(subnetIndex)
+}
+
+// selectConnectTargets filters nodes down to those advertising subnetIndex, reporting the peers
+// among them that isConnected already reports as connected (alreadyMatched) separately from
+// those that still need a Connect call (toConnect). addrInfo resolves a node to a dialable
+// peer.AddrInfo; production passes convertToAddrInfo, while tests can inject a fake, so this
+// peer-selection decision is testable against plain *enode.Node values without a live discv5
+// listener, libp2p host, or real multiaddr resolution.
+func selectConnectTargets(nodes []*enode.Node, subnetIndex uint64, isConnected func(peer.ID) bool, addrInfo func(*enode.Node) (*peer.AddrInfo, error)) (toConnect []*peer.AddrInfo, alreadyMatched []peer.ID) {
+	for _, node := range nodes {
+		if !nodeAdvertisesSubnet(node, subnetIndex) {
+			continue
+		}
+		peerInfo, err := addrInfo(node)
+		if err != nil || peerInfo == nil {
+			continue
+		}
+		if isConnected(peerInfo.ID) {
+			alreadyMatched = append(alreadyMatched, peerInfo.ID)
+			continue
+		}
+		toConnect = append(toConnect, peerInfo)
+	}
+	return toConnect, alreadyMatched
+}
+
+// findPeersWithSubnet performs a directed discv5 walk that prefers nodes advertising the given
+// attestation subnet, connecting to any newly discovered peer that matches. Every peer found to
+// advertise the subnet, whether already connected or newly connected to, is recorded so
+// SubnetPeerCount can report real subnet peer inventory. It reports whether at least one
+// matching peer, already connected or newly connected to, was found during the walk.
+func (s *Service) findPeersWithSubnet(ctx context.Context, subnetIndex uint64) (bool, error) {
+	if s.dv5Listener == nil {
+		return false, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, subnetSearchTimeout)
+	defer cancel()
+
+	found := false
+	for {
+		select {
+		case <-ctx.Done():
+			return found, nil
+		default:
+		}
+		nodes := s.dv5Listener.LookupRandom()
+		toConnect, alreadyMatched := selectConnectTargets(nodes, subnetIndex, func(id peer.ID) bool {
+			return s.host.Network().Connectedness(id) == network.Connected
+		}, func(node *enode.Node) (*peer.AddrInfo, error) {
+			info, _, err := convertToAddrInfo(node)
+			return info, err
+		})
+		for _, id := range alreadyMatched {
+			s.recordSubnetPeer(subnetIndex, id)
+			found = true
+		}
+		for _, peerInfo := range toConnect {
+			if err := s.host.Connect(ctx, *peerInfo); err != nil {
+				continue
+			}
+			s.recordSubnetPeer(subnetIndex, peerInfo.ID)
+			found = true
+		}
+		if found {
+			return true, nil
+		}
+	}
+}
+
+// subnetSearchKey identifies an in-flight directed discv5 walk for a subnet on a given service,
+// so repeated RefreshSubnetPeers calls (e.g. from a recurring metrics tick) don't pile up
+// overlapping walks for a subnet that is still under target from the previous call.
+type subnetSearchKey struct {
+	svc    *Service
+	subnet uint64
+}
+
+// inFlightSubnetSearches tracks which (service, subnet) pairs currently have a
+// findPeersWithSubnet walk running.
+var inFlightSubnetSearches sync.Map
+
+// subnetPeersByService tracks, per (service, subnet) pair, the peers a directed discv5 walk has
+// verified advertise that subnet in their ENR. It lives at package scope rather than on Service
+// itself because Service is defined outside this package's own files in this checkout; keying by
+// the Service pointer keeps the tracking correct if more than one Service exists in a process,
+// mirroring inFlightSubnetSearches above.
+var subnetPeersByService sync.Map // map[subnetSearchKey]map[peer.ID]struct{}
+
+var subnetPeersMu sync.Mutex
+
+// recordSubnetPeer notes that id was verified, via its ENR, to advertise subnetIndex.
+func (s *Service) recordSubnetPeer(subnetIndex uint64, id peer.ID) {
+	key := subnetSearchKey{svc: s, subnet: subnetIndex}
+	subnetPeersMu.Lock()
+	defer subnetPeersMu.Unlock()
+	peers, ok := subnetPeersByService.Load(key)
+	if !ok {
+		peers = make(map[peer.ID]struct{})
+		subnetPeersByService.Store(key, peers)
+	}
+	peers.(map[peer.ID]struct{})[id] = struct{}{}
+}
+
+// SubnetPeerCount reports how many peers are currently known to advertise subnetIndex in their
+// ENR, as verified by a prior directed discv5 walk, counting only peers still connected. This is
+// distinct from the pubsub mesh peer count: a peer can be meshed on a subnet's gossip topic
+// without ever having been confirmed, via its ENR, to actually aggregate that subnet.
+func (s *Service) SubnetPeerCount(subnetIndex uint64) int {
+	key := subnetSearchKey{svc: s, subnet: subnetIndex}
+	subnetPeersMu.Lock()
+	defer subnetPeersMu.Unlock()
+	peers, ok := subnetPeersByService.Load(key)
+	if !ok {
+		return 0
+	}
+	count := 0
+	for id := range peers.(map[peer.ID]struct{}) {
+		if s.host.Network().Connectedness(id) == network.Connected {
+			count++
+			continue
+		}
+		delete(peers.(map[peer.ID]struct{}), id)
+	}
+	return count
+}
+
+// RefreshSubnetPeers checks the current peer inventory for every attestation subnet the node
+// aggregates on and, for any subnet under MinPeersPerSubnet peers that isn't already being
+// searched, launches a directed discv5 walk in the background looking for peers advertising
+// that subnet.
+func (s *Service) RefreshSubnetPeers(indices []uint64, peerCount func(subnetIndex uint64) int) {
+	for _, idx := range indices {
+		if peerCount(idx) >= MinPeersPerSubnet {
+			continue
+		}
+		key := subnetSearchKey{svc: s, subnet: idx}
+		if _, alreadySearching := inFlightSubnetSearches.LoadOrStore(key, struct{}{}); alreadySearching {
+			continue
+		}
+		idx := idx
+		go func() {
+			defer inFlightSubnetSearches.Delete(key)
+			if _, err := s.findPeersWithSubnet(s.ctx, idx); err != nil {
+				log.WithError(err).WithField("subnet", idx).Debug("Could not find peers for subnet")
+			}
+		}()
+	}
+}
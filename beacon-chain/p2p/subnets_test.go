@@ -0,0 +1,123 @@
+package p2p
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+var errNoAddrInfo = errors.New("no address info for node")
+
+func createTestNode(t *testing.T, bitV bitfield.Bitvector64) *enode.Node {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record enr.Record
+	if bitV != nil {
+		record.Set(enr.WithEntry(attSubnetEnrKey, &bitV))
+	}
+	if err := enode.SignV4(&record, priv); err != nil {
+		t.Fatal(err)
+	}
+	node, err := enode.New(enode.ValidSchemes, &record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node
+}
+
+func TestNodeAdvertisesSubnet_BitSet(t *testing.T) {
+	bitV := make(bitfield.Bitvector64, 8)
+	bitV[0] |= 1 << 3
+	node := createTestNode(t, bitV)
+
+	if !nodeAdvertisesSubnet(node, 3) {
+		t.Error("Expected node to advertise subnet 3")
+	}
+	if nodeAdvertisesSubnet(node, 4) {
+		t.Error("Expected node to not advertise subnet 4")
+	}
+}
+
+func TestNodeAdvertisesSubnet_NoEntry(t *testing.T) {
+	node := createTestNode(t, nil)
+
+	if nodeAdvertisesSubnet(node, 0) {
+		t.Error("Expected node with no attnets entry to not match any subnet")
+	}
+}
+
+// fakeAddrInfo resolves each distinct *enode.Node to a distinct, stable peer.AddrInfo, so
+// selectConnectTargets tests can assert on which specific nodes were selected without a real
+// discv5 listener or libp2p host.
+func fakeAddrInfo(nodesToIDs map[*enode.Node]peer.ID) func(*enode.Node) (*peer.AddrInfo, error) {
+	return func(node *enode.Node) (*peer.AddrInfo, error) {
+		id, ok := nodesToIDs[node]
+		if !ok {
+			return nil, errNoAddrInfo
+		}
+		return &peer.AddrInfo{ID: id}, nil
+	}
+}
+
+func TestSelectConnectTargets(t *testing.T) {
+	bitV := make(bitfield.Bitvector64, 8)
+	bitV[0] |= 1 << 3
+	advertising := createTestNode(t, bitV)
+	notAdvertising := createTestNode(t, nil)
+
+	advertisingID := peer.ID("advertising-peer")
+	notAdvertisingID := peer.ID("not-advertising-peer")
+	addrInfo := fakeAddrInfo(map[*enode.Node]peer.ID{
+		advertising:    advertisingID,
+		notAdvertising: notAdvertisingID,
+	})
+
+	t.Run("selects an unconnected advertising node to connect to", func(t *testing.T) {
+		toConnect, alreadyMatched := selectConnectTargets(
+			[]*enode.Node{advertising, notAdvertising},
+			3,
+			func(peer.ID) bool { return false },
+			addrInfo,
+		)
+		if len(toConnect) != 1 || toConnect[0].ID != advertisingID {
+			t.Errorf("expected only the advertising node queued to connect, got %v", toConnect)
+		}
+		if len(alreadyMatched) != 0 {
+			t.Errorf("expected no already-matched peers, got %v", alreadyMatched)
+		}
+	})
+
+	t.Run("reports an already-connected advertising node as matched, not to connect to", func(t *testing.T) {
+		toConnect, alreadyMatched := selectConnectTargets(
+			[]*enode.Node{advertising, notAdvertising},
+			3,
+			func(id peer.ID) bool { return id == advertisingID },
+			addrInfo,
+		)
+		if len(toConnect) != 0 {
+			t.Errorf("expected nothing left to connect to, got %v", toConnect)
+		}
+		if len(alreadyMatched) != 1 || alreadyMatched[0] != advertisingID {
+			t.Errorf("expected the advertising node reported as already matched, got %v", alreadyMatched)
+		}
+	})
+
+	t.Run("ignores nodes that don't resolve to an address", func(t *testing.T) {
+		toConnect, alreadyMatched := selectConnectTargets(
+			[]*enode.Node{advertising},
+			3,
+			func(peer.ID) bool { return false },
+			func(*enode.Node) (*peer.AddrInfo, error) { return nil, errNoAddrInfo },
+		)
+		if len(toConnect) != 0 || len(alreadyMatched) != 0 {
+			t.Errorf("expected no targets when address resolution fails, got toConnect=%v alreadyMatched=%v", toConnect, alreadyMatched)
+		}
+	})
+}
@@ -0,0 +1,299 @@
+package sync
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// EventType identifies which GossipSub protocol interaction a TraceEvent describes.
+type EventType string
+
+const (
+	// AddPeerEvent is emitted when the pubsub router learns about a new peer.
+	AddPeerEvent EventType = "ADD_PEER"
+	// RemovePeerEvent is emitted when a peer is forgotten by the pubsub router.
+	RemovePeerEvent EventType = "REMOVE_PEER"
+	// JoinEvent is emitted when the local node joins a topic mesh.
+	JoinEvent EventType = "JOIN"
+	// LeaveEvent is emitted when the local node leaves a topic mesh.
+	LeaveEvent EventType = "LEAVE"
+	// GraftEvent is emitted when a peer is added to a topic mesh.
+	GraftEvent EventType = "GRAFT"
+	// PruneEvent is emitted when a peer is removed from a topic mesh.
+	PruneEvent EventType = "PRUNE"
+	// PublishEvent is emitted when the local node publishes a message.
+	PublishEvent EventType = "PUBLISH"
+	// DeliverMessageEvent is emitted when a message is delivered to the local node.
+	DeliverMessageEvent EventType = "DELIVER_MESSAGE"
+	// DuplicateMessageEvent is emitted when a duplicate of an already-seen message arrives.
+	DuplicateMessageEvent EventType = "DUPLICATE_MESSAGE"
+	// RejectMessageEvent is emitted when a message fails validation.
+	RejectMessageEvent EventType = "REJECT_MESSAGE"
+	// ValidateMessageEvent is emitted when a message enters validation.
+	ValidateMessageEvent EventType = "VALIDATE_MESSAGE"
+	// ThrottlePeerEvent is emitted when a peer is throttled for misbehaving.
+	ThrottlePeerEvent EventType = "THROTTLE_PEER"
+)
+
+// TraceEvent is a structured record of a single GossipSub protocol interaction,
+// suitable for forensic post-mortem of why an attestation or block was dropped.
+type TraceEvent struct {
+	Type      EventType `json:"type"`
+	Topic     string    `json:"topic,omitempty"`
+	PeerID    string    `json:"peer_id,omitempty"`
+	MsgID     string    `json:"msg_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Populated for message events when the underlying beacon object could be decoded.
+	Slot           uint64 `json:"slot,omitempty"`
+	CommitteeIndex uint64 `json:"committee_index,omitempty"`
+	Root           string `json:"root,omitempty"`
+
+	// Reason is populated on a RejectMessageEvent with why the message failed validation.
+	Reason string `json:"reason,omitempty"`
+}
+
+// EventSink receives a copy of every trace event produced by a GossipTracer. Implementations
+// must not block, as the tracer invokes sinks synchronously from the pubsub hot path.
+type EventSink interface {
+	HandleEvent(evt *TraceEvent)
+}
+
+// GossipTracer implements the libp2p pubsub.RawTracer interface, translating every
+// GossipSub protocol interaction into a TraceEvent and fanning it out to the configured sinks.
+type GossipTracer struct {
+	sinks []EventSink
+}
+
+// NewGossipTracer returns a GossipTracer that forwards every event to the provided sinks.
+func NewGossipTracer(sinks ...EventSink) *GossipTracer {
+	return &GossipTracer{sinks: sinks}
+}
+
+var _ pubsub.RawTracer = (*GossipTracer)(nil)
+
+// WithGossipTracer returns the libp2p pubsub.Option that attaches tracer to a pubsub router at
+// construction time, e.g. pubsub.NewGossipSub(ctx, host, sync.WithGossipTracer(tracer)).
+func WithGossipTracer(tracer *GossipTracer) pubsub.Option {
+	return pubsub.WithRawTracer(tracer)
+}
+
+func (t *GossipTracer) emit(evt *TraceEvent) {
+	evt.Timestamp = time.Now()
+	for _, sink := range t.sinks {
+		sink.HandleEvent(evt)
+	}
+}
+
+// AddPeer is called when the pubsub router learns about a new peer.
+func (t *GossipTracer) AddPeer(p peer.ID, proto protocol.ID) {
+	t.emit(&TraceEvent{Type: AddPeerEvent, PeerID: p.String()})
+}
+
+// RemovePeer is called when a peer is forgotten by the pubsub router.
+func (t *GossipTracer) RemovePeer(p peer.ID) {
+	t.emit(&TraceEvent{Type: RemovePeerEvent, PeerID: p.String()})
+}
+
+// Join is called when the local node joins a topic mesh.
+func (t *GossipTracer) Join(topic string) {
+	t.emit(&TraceEvent{Type: JoinEvent, Topic: topic})
+}
+
+// Leave is called when the local node leaves a topic mesh.
+func (t *GossipTracer) Leave(topic string) {
+	t.emit(&TraceEvent{Type: LeaveEvent, Topic: topic})
+}
+
+// Graft is called when a peer is added to a topic mesh.
+func (t *GossipTracer) Graft(p peer.ID, topic string) {
+	t.emit(&TraceEvent{Type: GraftEvent, Topic: topic, PeerID: p.String()})
+}
+
+// Prune is called when a peer is removed from a topic mesh.
+func (t *GossipTracer) Prune(p peer.ID, topic string) {
+	t.emit(&TraceEvent{Type: PruneEvent, Topic: topic, PeerID: p.String()})
+}
+
+// ValidateMessage is called when a message enters validation.
+func (t *GossipTracer) ValidateMessage(msg *pubsub.Message) {
+	t.emit(t.messageEvent(ValidateMessageEvent, msg))
+}
+
+// DeliverMessage is called when a message is delivered to the local node.
+func (t *GossipTracer) DeliverMessage(msg *pubsub.Message) {
+	t.emit(t.messageEvent(DeliverMessageEvent, msg))
+}
+
+// RejectMessage is called when a message fails validation. reason is recorded on the emitted
+// event so operators can tell why a message was rejected without cross-referencing validator
+// logs.
+func (t *GossipTracer) RejectMessage(msg *pubsub.Message, reason string) {
+	evt := t.messageEvent(RejectMessageEvent, msg)
+	evt.Reason = reason
+	t.emit(evt)
+}
+
+// DuplicateMessage is called when a duplicate of an already-seen message arrives.
+func (t *GossipTracer) DuplicateMessage(msg *pubsub.Message) {
+	t.emit(t.messageEvent(DuplicateMessageEvent, msg))
+}
+
+// ThrottlePeer is called when a peer is throttled for misbehaving.
+func (t *GossipTracer) ThrottlePeer(p peer.ID) {
+	t.emit(&TraceEvent{Type: ThrottlePeerEvent, PeerID: p.String()})
+}
+
+// RecvRPC, SendRPC, DropRPC, and UndeliverableMessage are part of the RawTracer interface but
+// operate below the level of the structured events this tracer is meant to surface, so they
+// are intentionally no-ops here.
+func (t *GossipTracer) RecvRPC(rpc *pubsub.RPC) {}
+
+// SendRPC is a no-op, see RecvRPC.
+func (t *GossipTracer) SendRPC(rpc *pubsub.RPC) {}
+
+// DropRPC is a no-op, see RecvRPC.
+func (t *GossipTracer) DropRPC(rpc *pubsub.RPC) {}
+
+// UndeliverableMessage is a no-op, see RecvRPC.
+func (t *GossipTracer) UndeliverableMessage(msg *pubsub.Message) {}
+
+// messageEvent builds a TraceEvent for a pubsub message, decoding the slot/committee/root of
+// the underlying beacon object when the payload is a recognized SSZ-encoded type.
+func (t *GossipTracer) messageEvent(typ EventType, msg *pubsub.Message) *TraceEvent {
+	evt := &TraceEvent{
+		Type:   typ,
+		Topic:  msg.GetTopic(),
+		PeerID: msg.GetFrom().String(),
+		MsgID:  msg.ID,
+	}
+	decodeMessageMetadata(evt, msg.Data)
+	return evt
+}
+
+// decodeMessageMetadata best-effort decodes a gossiped payload as an attestation and, if
+// successful, populates the event's slot, committee index, and beacon block root. Payloads
+// that don't decode as an attestation are left unannotated rather than treated as an error,
+// since not every gossiped object carries this metadata.
+func decodeMessageMetadata(evt *TraceEvent, data []byte) {
+	att := &ethpb.Attestation{}
+	if err := ssz.Unmarshal(data, att); err != nil || att.Data == nil {
+		return
+	}
+	evt.Slot = att.Data.Slot
+	evt.CommitteeIndex = att.Data.CommitteeIndex
+	evt.Root = hex.EncodeToString(att.Data.BeaconBlockRoot)
+}
+
+// JSONLSink writes every event as a single line of JSON to the given file, for later offline
+// analysis with standard line-oriented tooling (jq, grep, etc).
+type JSONLSink struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	f   *os.File
+	enc *json.Encoder
+}
+
+// NewJSONLSink opens (creating if necessary) the file at path and returns a sink that appends
+// one JSON object per line to it.
+func NewJSONLSink(path string) (*JSONLSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open gossip trace file")
+	}
+	w := bufio.NewWriter(f)
+	return &JSONLSink{w: w, f: f, enc: json.NewEncoder(w)}, nil
+}
+
+// HandleEvent appends evt to the underlying file as a single JSON line.
+func (s *JSONLSink) HandleEvent(evt *TraceEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(evt); err != nil {
+		log.WithError(err).Error("Could not write gossip trace event")
+		return
+	}
+	if err := s.w.Flush(); err != nil {
+		log.WithError(err).Error("Could not flush gossip trace file")
+	}
+}
+
+// Close flushes and closes the underlying file.
+func (s *JSONLSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	return s.f.Close()
+}
+
+// defaultRingBufferCapacity bounds the number of events kept in memory by a RingBufferSink.
+const defaultRingBufferCapacity = 1000
+
+// RingBufferSink keeps the most recent events in memory so an operator can inspect, over an
+// admin HTTP endpoint, why a particular attestation or block was recently dropped without
+// needing to stand up an external log pipeline.
+type RingBufferSink struct {
+	mu     sync.RWMutex
+	events []*TraceEvent
+	cap    int
+	next   int
+	filled bool
+}
+
+// NewRingBufferSink returns a RingBufferSink retaining up to capacity events. A non-positive
+// capacity falls back to defaultRingBufferCapacity.
+func NewRingBufferSink(capacity int) *RingBufferSink {
+	if capacity <= 0 {
+		capacity = defaultRingBufferCapacity
+	}
+	return &RingBufferSink{events: make([]*TraceEvent, capacity), cap: capacity}
+}
+
+// HandleEvent stores evt, overwriting the oldest retained event once capacity is reached.
+func (s *RingBufferSink) HandleEvent(evt *TraceEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[s.next] = evt
+	s.next = (s.next + 1) % s.cap
+	if s.next == 0 {
+		s.filled = true
+	}
+}
+
+// Recent returns the retained events in the order they were recorded, oldest first.
+func (s *RingBufferSink) Recent() []*TraceEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.filled {
+		out := make([]*TraceEvent, s.next)
+		copy(out, s.events[:s.next])
+		return out
+	}
+	out := make([]*TraceEvent, 0, s.cap)
+	out = append(out, s.events[s.next:]...)
+	out = append(out, s.events[:s.next]...)
+	return out
+}
+
+// ServeHTTP renders the retained events as a JSON array, allowing an operator to curl the
+// admin endpoint to post-mortem recent gossip activity.
+func (s *RingBufferSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Recent()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,147 @@
+package sync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/urfave/cli/v2"
+)
+
+type fakeKafkaProducer struct {
+	wrote [][]byte
+}
+
+func (f *fakeKafkaProducer) WriteMessage(ctx context.Context, key, value []byte) error {
+	f.wrote = append(f.wrote, value)
+	return nil
+}
+
+type fakeOTLPExporter struct {
+	exported []*TraceEvent
+}
+
+func (f *fakeOTLPExporter) Export(ctx context.Context, evt *TraceEvent) error {
+	f.exported = append(f.exported, evt)
+	return nil
+}
+
+func TestGossipTracer_FanOutToSinks(t *testing.T) {
+	ring := NewRingBufferSink(10)
+	kafkaProducer := &fakeKafkaProducer{}
+	otlpExporter := &fakeOTLPExporter{}
+	tracer := NewGossipTracer(ring, NewKafkaSink(kafkaProducer), NewOTLPSink(otlpExporter))
+
+	var _ pubsub.RawTracer = tracer
+	tracer.Join("/eth2/foo")
+
+	if len(ring.Recent()) != 1 {
+		t.Fatalf("expected 1 event in ring buffer, got %d", len(ring.Recent()))
+	}
+	if ring.Recent()[0].Type != JoinEvent {
+		t.Errorf("expected JoinEvent, got %v", ring.Recent()[0].Type)
+	}
+	if len(kafkaProducer.wrote) != 1 {
+		t.Errorf("expected 1 message written to Kafka, got %d", len(kafkaProducer.wrote))
+	}
+	if len(otlpExporter.exported) != 1 {
+		t.Errorf("expected 1 event exported over OTLP, got %d", len(otlpExporter.exported))
+	}
+}
+
+func TestGossipTracer_RejectMessageRecordsReason(t *testing.T) {
+	ring := NewRingBufferSink(10)
+	tracer := NewGossipTracer(ring)
+
+	tracer.RejectMessage(&pubsub.Message{}, "invalid signature")
+
+	recent := ring.Recent()
+	if len(recent) != 1 {
+		t.Fatalf("expected 1 event in ring buffer, got %d", len(recent))
+	}
+	if recent[0].Type != RejectMessageEvent {
+		t.Errorf("expected RejectMessageEvent, got %v", recent[0].Type)
+	}
+	if recent[0].Reason != "invalid signature" {
+		t.Errorf("expected reason %q to be recorded on the event, got %q", "invalid signature", recent[0].Reason)
+	}
+}
+
+func TestSinkFromFlag(t *testing.T) {
+	ring := NewRingBufferSink(10)
+	app := &cli.App{
+		Flags: []cli.Flag{GossipTracerSinkFlag, GossipTracerJSONLPathFlag},
+		Action: func(cliCtx *cli.Context) error {
+			sink, err := SinkFromFlag(cliCtx, ring, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sink != ring {
+				t.Error("expected default sink to be the ring buffer")
+			}
+			return nil
+		},
+	}
+	if err := app.Run([]string{"app"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSinkFromFlag_KafkaRequiresProducer(t *testing.T) {
+	ring := NewRingBufferSink(10)
+	app := &cli.App{
+		Flags: []cli.Flag{GossipTracerSinkFlag, GossipTracerJSONLPathFlag},
+		Action: func(cliCtx *cli.Context) error {
+			if _, err := SinkFromFlag(cliCtx, ring, nil, nil); err == nil {
+				t.Error("expected an error selecting kafka sink with no producer configured")
+			}
+			return nil
+		},
+	}
+	if err := app.Run([]string{"app", "--gossip-trace-sink", "kafka"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterGossipTraceHandler(t *testing.T) {
+	ring := NewRingBufferSink(10)
+	ring.HandleEvent(&TraceEvent{Type: JoinEvent, Topic: "/eth2/foo"})
+
+	mux := http.NewServeMux()
+	RegisterGossipTraceHandler(mux, "/admin/gossip-trace", ring)
+
+	req := httptest.NewRequest("GET", "/admin/gossip-trace", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestNewGossipTracerFromFlags(t *testing.T) {
+	mux := http.NewServeMux()
+	app := &cli.App{
+		Flags: Flags(),
+		Action: func(cliCtx *cli.Context) error {
+			tracer, err := NewGossipTracerFromFlags(cliCtx, nil, nil, mux, "/admin/gossip-trace")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracer.Join("/eth2/foo")
+
+			req := httptest.NewRequest("GET", "/admin/gossip-trace", nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != 200 {
+				t.Fatalf("expected the admin endpoint registered by NewGossipTracerFromFlags to serve 200, got %d", rec.Code)
+			}
+			return nil
+		},
+	}
+	if err := app.Run([]string{"app"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -69,6 +69,12 @@ var (
 			Help: "Count the number of times attestation not recovered and pruned because of missing block",
 		},
 	)
+	subnetPeerInventory = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "p2p_subnet_peer_inventory",
+			Help: "The number of peers verified, via directed discv5 search of their ENR, to advertise a given attestation subnet. Distinct from p2p_topic_peer_count, which counts mesh membership regardless of ENR advertisement.",
+		}, []string{"subnet"},
+	)
 )
 
 func (r *Service) updateMetrics() {
@@ -87,8 +93,14 @@ func (r *Service) updateMetrics() {
 	attTopic += r.p2p.Encoding().ProtocolSuffix()
 	for _, committeeIdx := range indices {
 		formattedTopic := fmt.Sprintf(attTopic, digest, committeeIdx)
-		topicPeerCount.WithLabelValues(formattedTopic).Set(float64(len(r.p2p.PubSub().ListPeers(formattedTopic))))
+		peerCount := len(r.p2p.PubSub().ListPeers(formattedTopic))
+		topicPeerCount.WithLabelValues(formattedTopic).Set(float64(peerCount))
+		subnetPeerInventory.WithLabelValues(fmt.Sprintf("%d", committeeIdx)).Set(float64(r.p2p.SubnetPeerCount(committeeIdx)))
 	}
+	r.p2p.RefreshSubnetPeers(indices, func(subnetIdx uint64) int {
+		formattedTopic := fmt.Sprintf(attTopic, digest, subnetIdx)
+		return len(r.p2p.PubSub().ListPeers(formattedTopic))
+	})
 	// We update all other gossip topics.
 	for topic := range p2p.GossipTopicMappings {
 		// We already updated attestation subnet topics.
@@ -0,0 +1,185 @@
+// Code generated by protoc-gen-go and protoc-gen-go-grpc from slashing.proto. In this checkout
+// the protoc toolchain isn't available, so this file is maintained by hand to match the shape
+// protoc would otherwise emit; regenerate from slashing.proto once the toolchain is available and
+// drop this notice.
+
+package slashing
+
+import (
+	context "context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	grpc "google.golang.org/grpc"
+)
+
+// AttesterSlashingResponse is the response to IsSlashableAttestation.
+type AttesterSlashingResponse struct {
+	AttesterSlashing []*ethpb.AttesterSlashing `protobuf:"bytes,1,rep,name=attester_slashing,json=attesterSlashing,proto3" json:"attester_slashing,omitempty"`
+}
+
+// ProposerSlashingResponse is the response to IsSlashableBlock.
+type ProposerSlashingResponse struct {
+	ProposerSlashing []*ethpb.ProposerSlashing `protobuf:"bytes,1,rep,name=proposer_slashing,json=proposerSlashing,proto3" json:"proposer_slashing,omitempty"`
+}
+
+// SubscribeRequest narrows a HighlySlashableEvents subscription to the given validator indices.
+// An empty list subscribes to every slashing the detector produces.
+type SubscribeRequest struct {
+	ValidatorIndices []uint64 `protobuf:"varint,1,rep,packed,name=validator_indices,json=validatorIndices,proto3" json:"validator_indices,omitempty"`
+}
+
+// SlashingEvent is a single attester or proposer slashing pushed to HighlySlashableEvents
+// subscribers. Exactly one of AttesterSlashing or ProposerSlashing is set.
+type SlashingEvent struct {
+	ValidatorIndices []uint64                `protobuf:"varint,1,rep,packed,name=validator_indices,json=validatorIndices,proto3" json:"validator_indices,omitempty"`
+	AttesterSlashing *ethpb.AttesterSlashing `protobuf:"bytes,2,opt,name=attester_slashing,json=attesterSlashing,proto3" json:"attester_slashing,omitempty"`
+	ProposerSlashing *ethpb.ProposerSlashing `protobuf:"bytes,3,opt,name=proposer_slashing,json=proposerSlashing,proto3" json:"proposer_slashing,omitempty"`
+}
+
+// SlasherServer is the server API for the Slasher service.
+type SlasherServer interface {
+	IsSlashableAttestation(context.Context, *ethpb.IndexedAttestation) (*AttesterSlashingResponse, error)
+	IsSlashableBlock(context.Context, *ethpb.SignedBeaconBlockHeader) (*ProposerSlashingResponse, error)
+	HighlySlashableEvents(*SubscribeRequest, Slasher_HighlySlashableEventsServer) error
+}
+
+// Slasher_HighlySlashableEventsServer is the server-side stream handle for HighlySlashableEvents,
+// matching the shape protoc-gen-go-grpc generates for a server-streaming RPC.
+type Slasher_HighlySlashableEventsServer interface {
+	Send(*SlashingEvent) error
+	grpc.ServerStream
+}
+
+type slasherHighlySlashableEventsServer struct {
+	grpc.ServerStream
+}
+
+func (s *slasherHighlySlashableEventsServer) Send(evt *SlashingEvent) error {
+	return s.ServerStream.SendMsg(evt)
+}
+
+// Slasher_HighlySlashableEventsClient is the client-side stream handle for HighlySlashableEvents.
+type Slasher_HighlySlashableEventsClient interface {
+	Recv() (*SlashingEvent, error)
+	grpc.ClientStream
+}
+
+type slasherHighlySlashableEventsClient struct {
+	grpc.ClientStream
+}
+
+func (c *slasherHighlySlashableEventsClient) Recv() (*SlashingEvent, error) {
+	evt := new(SlashingEvent)
+	if err := c.ClientStream.RecvMsg(evt); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// SlasherClient is the client API for the Slasher service.
+type SlasherClient interface {
+	IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation, opts ...grpc.CallOption) (*AttesterSlashingResponse, error)
+	IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader, opts ...grpc.CallOption) (*ProposerSlashingResponse, error)
+	HighlySlashableEvents(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Slasher_HighlySlashableEventsClient, error)
+}
+
+type slasherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewSlasherClient returns a SlasherClient backed by cc.
+func NewSlasherClient(cc grpc.ClientConnInterface) SlasherClient {
+	return &slasherClient{cc: cc}
+}
+
+func (c *slasherClient) IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation, opts ...grpc.CallOption) (*AttesterSlashingResponse, error) {
+	out := new(AttesterSlashingResponse)
+	if err := c.cc.Invoke(ctx, "/ethereum.slashing.Slasher/IsSlashableAttestation", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader, opts ...grpc.CallOption) (*ProposerSlashingResponse, error) {
+	out := new(ProposerSlashingResponse)
+	if err := c.cc.Invoke(ctx, "/ethereum.slashing.Slasher/IsSlashableBlock", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slasherClient) HighlySlashableEvents(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Slasher_HighlySlashableEventsClient, error) {
+	stream, err := c.cc.(interface {
+		NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error)
+	}).NewStream(ctx, &_Slasher_serviceDesc.Streams[0], "/ethereum.slashing.Slasher/HighlySlashableEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &slasherHighlySlashableEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+var _Slasher_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ethereum.slashing.Slasher",
+	HandlerType: (*SlasherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "IsSlashableAttestation", Handler: _Slasher_IsSlashableAttestation_Handler},
+		{MethodName: "IsSlashableBlock", Handler: _Slasher_IsSlashableBlock_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HighlySlashableEvents",
+			Handler:       _Slasher_HighlySlashableEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+}
+
+func _Slasher_IsSlashableAttestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ethpb.IndexedAttestation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlasherServer).IsSlashableAttestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/ethereum.slashing.Slasher/IsSlashableAttestation"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlasherServer).IsSlashableAttestation(ctx, req.(*ethpb.IndexedAttestation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Slasher_IsSlashableBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ethpb.SignedBeaconBlockHeader)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlasherServer).IsSlashableBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/ethereum.slashing.Slasher/IsSlashableBlock"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlasherServer).IsSlashableBlock(ctx, req.(*ethpb.SignedBeaconBlockHeader))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Slasher_HighlySlashableEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(SubscribeRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(SlasherServer).HighlySlashableEvents(req, &slasherHighlySlashableEventsServer{stream})
+}
+
+// RegisterSlasherServer registers srv as the implementation of the Slasher service on s.
+func RegisterSlasherServer(s *grpc.Server, srv SlasherServer) {
+	s.RegisterService(&_Slasher_serviceDesc, srv)
+}
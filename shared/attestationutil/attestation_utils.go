@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -147,3 +148,126 @@ func VerifyIndexedAttestation(ctx context.Context, indexedAtt *ethpb.IndexedAtte
 	}
 	return nil
 }
+
+// AttestationPubkeys is the set of validator public keys that participated in a single
+// indexed attestation, in the same order the attestation is passed to VerifyIndexedAttestations.
+type AttestationPubkeys []*bls.PublicKey
+
+// VerifyIndexedAttestations verifies many indexed attestations, one call for many callers that
+// would otherwise each call VerifyIndexedAttestation separately. The only batching this does is
+// de-duplicating signing-root computation across attestations that share the same
+// AttestationData (a common case when many attesters vote for the same checkpoint), and it
+// still spends one FastAggregateVerify call per attestation since shared/bls does not expose a
+// single-pairing multi-signature verifier.
+//
+// KNOWN LIMITATION: the original ask for this function was single-pairing, randomized-coefficient
+// batch verification across all of atts at once, which would amortize pairing cost across the
+// whole batch instead of paying it once per attestation. That has not been implemented; this is
+// the FastAggregateVerify-per-attestation fallback described above, kept only because it's still
+// correct and shared/bls has no batch-verification API to build the real thing on. Treat the
+// performance goal of the original request as still open, not delivered by this function.
+//
+// The returned error slice is one-to-one with atts; a nil error at index i means attestation i
+// verified. The second return value is only non-nil for malformed input that prevents any
+// verification from happening at all.
+func VerifyIndexedAttestations(ctx context.Context, atts []*ethpb.IndexedAttestation, pubKeys []AttestationPubkeys, genesisValidatorsRoot []byte, fork *pb.Fork) ([]error, error) {
+	ctx, span := trace.StartSpan(ctx, "attestationutil.VerifyIndexedAttestations")
+	defer span.End()
+	if len(atts) != len(pubKeys) {
+		return nil, fmt.Errorf("mismatched number of attestations and pubkey sets, %d != %d", len(atts), len(pubKeys))
+	}
+	if len(atts) == 0 {
+		return nil, nil
+	}
+
+	messageHashes := make([][32]byte, len(atts))
+	seenRoots := make(map[[32]byte][32]byte)
+	for i, att := range atts {
+		if att == nil || att.Data == nil || att.Data.Target == nil {
+			return nil, errors.New("nil or missing indexed attestation data")
+		}
+		dataRoot, err := helpers.ComputeSigningRoot(att.Data, make([]byte, 32))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash attestation data")
+		}
+		if root, ok := seenRoots[dataRoot]; ok {
+			messageHashes[i] = root
+			continue
+		}
+		domain, err := helpers.Domain(fork, att.Data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, genesisValidatorsRoot)
+		if err != nil {
+			return nil, err
+		}
+		root, err := helpers.ComputeSigningRoot(att.Data, domain)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get signing root of object")
+		}
+		seenRoots[dataRoot] = root
+		messageHashes[i] = root
+	}
+
+	errs := make([]error, len(atts))
+	for i, att := range atts {
+		sig, err := bls.SignatureFromBytes(att.Signature)
+		if err != nil {
+			errs[i] = errors.Wrap(err, "could not convert bytes to signature")
+			continue
+		}
+		voted := len(att.AttestingIndices) > 0
+		if voted && !sig.FastAggregateVerify(pubKeys[i], messageHashes[i]) {
+			errs[i] = helpers.ErrSigFailedToVerify
+		}
+	}
+	return errs, nil
+}
+
+// BatchVerifier accumulates indexed attestations so they can be verified together via
+// VerifyIndexedAttestations, reporting via ReadyToFlush once enqueued work has waited at least its
+// configured deadline. It does not flush by itself: the caller decides when to call Verify. It
+// is intended for callers, such as the slasher, that receive attestations one at a time but want
+// to drain a backlog of them together.
+type BatchVerifier struct {
+	deadline              time.Duration
+	genesisValidatorsRoot []byte
+	fork                  *pb.Fork
+
+	atts    []*ethpb.IndexedAttestation
+	pubKeys []AttestationPubkeys
+	oldest  time.Time
+}
+
+// NewBatchVerifier returns a BatchVerifier that verifies against the given fork and genesis
+// validators root, whose ReadyToFlush reports true once the oldest enqueued attestation has been
+// waiting at least deadline.
+func NewBatchVerifier(genesisValidatorsRoot []byte, fork *pb.Fork, deadline time.Duration) *BatchVerifier {
+	return &BatchVerifier{
+		deadline:              deadline,
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		fork:                  fork,
+	}
+}
+
+// Enqueue adds an indexed attestation and its participants' public keys to the pending batch.
+func (b *BatchVerifier) Enqueue(att *ethpb.IndexedAttestation, pubKeys AttestationPubkeys) {
+	if len(b.atts) == 0 {
+		b.oldest = time.Now()
+	}
+	b.atts = append(b.atts, att)
+	b.pubKeys = append(b.pubKeys, pubKeys)
+}
+
+// ReadyToFlush reports whether the batch is non-empty and its oldest member has been waiting
+// at least as long as the configured deadline.
+func (b *BatchVerifier) ReadyToFlush() bool {
+	return len(b.atts) > 0 && time.Now().Sub(b.oldest) >= b.deadline
+}
+
+// Verify runs batch verification over every currently enqueued attestation and clears the
+// queue, regardless of whether the deadline has elapsed. Callers on a streaming path should
+// gate calls to Verify with ReadyToFlush, or call it directly to force an early flush.
+func (b *BatchVerifier) Verify(ctx context.Context) ([]error, error) {
+	errs, err := VerifyIndexedAttestations(ctx, b.atts, b.pubKeys, b.genesisValidatorsRoot, b.fork)
+	b.atts = nil
+	b.pubKeys = nil
+	return errs, err
+}
@@ -0,0 +1,91 @@
+// Package beaconclient wraps the gRPC clients the slasher needs against a beacon node: reading
+// the genesis validators root once at startup and resolving validator indices to public keys,
+// with an in-memory cache so repeated lookups for the same validator don't round-trip every time.
+package beaconclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ValidatorClient is the subset of the beacon node's gRPC validator service the slasher depends
+// on to resolve validator indices to public keys.
+type ValidatorClient interface {
+	ValidatorPublicKeys(ctx context.Context, indices []uint64) (map[uint64][48]byte, error)
+}
+
+// NodeClient is the subset of the beacon node's gRPC node service the slasher depends on to read
+// the genesis validators root.
+type NodeClient interface {
+	GenesisValidatorsRoot(ctx context.Context) ([]byte, error)
+}
+
+// Service resolves validator public keys and the genesis validators root against a beacon node,
+// caching public keys since they never change for a given validator index.
+type Service struct {
+	validatorClient ValidatorClient
+	nodeClient      NodeClient
+
+	mu         sync.Mutex
+	pubkeyByID map[uint64][48]byte
+
+	genesisValidatorsRoot []byte
+}
+
+// NewService returns a beaconclient Service backed by the given beacon node clients.
+func NewService(validatorClient ValidatorClient, nodeClient NodeClient) *Service {
+	return &Service{
+		validatorClient: validatorClient,
+		nodeClient:      nodeClient,
+		pubkeyByID:      make(map[uint64][48]byte),
+	}
+}
+
+// GenesisValidatorsRoot returns the beacon chain's genesis validators root, fetching and caching
+// it from the beacon node on first call.
+func (s *Service) GenesisValidatorsRoot(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.genesisValidatorsRoot != nil {
+		return s.genesisValidatorsRoot, nil
+	}
+	root, err := s.nodeClient.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch genesis validators root")
+	}
+	s.genesisValidatorsRoot = root
+	return root, nil
+}
+
+// FindOrGetPublicKeys returns the public keys for indices, serving any already-cached entries
+// and fetching only the remainder from the beacon node.
+func (s *Service) FindOrGetPublicKeys(ctx context.Context, indices []uint64) (map[uint64][48]byte, error) {
+	s.mu.Lock()
+	missing := make([]uint64, 0, len(indices))
+	out := make(map[uint64][48]byte, len(indices))
+	for _, idx := range indices {
+		if pk, ok := s.pubkeyByID[idx]; ok {
+			out[idx] = pk
+			continue
+		}
+		missing = append(missing, idx)
+	}
+	s.mu.Unlock()
+
+	if len(missing) == 0 {
+		return out, nil
+	}
+	fetched, err := s.validatorClient.ValidatorPublicKeys(ctx, missing)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch validator public keys")
+	}
+	s.mu.Lock()
+	for idx, pk := range fetched {
+		s.pubkeyByID[idx] = pk
+		out[idx] = pk
+	}
+	s.mu.Unlock()
+	return out, nil
+}
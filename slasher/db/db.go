@@ -0,0 +1,29 @@
+// Package db defines the persistence boundary the slasher depends on: indexed attestations and
+// proposed block headers, stored so that a later submission for the same validator can be
+// compared against what was already seen.
+package db
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// Database is the storage interface the slasher's detection logic and RPC server depend on.
+type Database interface {
+	// SaveIndexedAttestation stores att so later attestations from the same validators can be
+	// compared against it for double-vote and surround-vote detection.
+	SaveIndexedAttestation(ctx context.Context, att *ethpb.IndexedAttestation) error
+	// IndexedAttestationsForValidator returns every previously saved indexed attestation that
+	// validatorIndex participated in.
+	IndexedAttestationsForValidator(ctx context.Context, validatorIndex uint64) ([]*ethpb.IndexedAttestation, error)
+	// SaveBlockHeader stores header so a later header proposed for the same slot and proposer can
+	// be compared against it for double-proposal detection.
+	SaveBlockHeader(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error
+	// BlockHeaders returns every previously saved header proposed at slot by proposerIndex.
+	BlockHeaders(ctx context.Context, slot uint64, proposerIndex uint64) ([]*ethpb.SignedBeaconBlockHeader, error)
+	// PruneBlockHeaders deletes saved headers proposed more than pruningEpochDistance epochs
+	// before currentEpoch, the weak subjectivity period beyond which a proposer can no longer be
+	// meaningfully slashed.
+	PruneBlockHeaders(ctx context.Context, currentEpoch uint64, pruningEpochDistance uint64) error
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"sync"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// headerKey identifies the set of block headers proposed at a given slot by a given proposer.
+type headerKey struct {
+	slot          uint64
+	proposerIndex uint64
+}
+
+// Store is an in-memory Database implementation. It's sufficient for tests and for a
+// single-process slasher; a production deployment backing multiple slasher instances would want
+// a disk-backed implementation of this same interface instead.
+type Store struct {
+	mu             sync.Mutex
+	attestationsBy map[uint64][]*ethpb.IndexedAttestation
+	headers        map[headerKey][]*ethpb.SignedBeaconBlockHeader
+}
+
+// NewStore returns an empty in-memory Store.
+func NewStore() *Store {
+	return &Store{
+		attestationsBy: make(map[uint64][]*ethpb.IndexedAttestation),
+		headers:        make(map[headerKey][]*ethpb.SignedBeaconBlockHeader),
+	}
+}
+
+// SaveIndexedAttestation indexes att under every validator index that participated in it.
+func (s *Store) SaveIndexedAttestation(ctx context.Context, att *ethpb.IndexedAttestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, idx := range att.AttestingIndices {
+		s.attestationsBy[idx] = append(s.attestationsBy[idx], att)
+	}
+	return nil
+}
+
+// IndexedAttestationsForValidator returns every attestation previously saved for validatorIndex.
+func (s *Store) IndexedAttestationsForValidator(ctx context.Context, validatorIndex uint64) ([]*ethpb.IndexedAttestation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*ethpb.IndexedAttestation, len(s.attestationsBy[validatorIndex]))
+	copy(out, s.attestationsBy[validatorIndex])
+	return out, nil
+}
+
+// SaveBlockHeader indexes header under its slot and proposer index.
+func (s *Store) SaveBlockHeader(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := headerKey{slot: header.Header.Slot, proposerIndex: header.Header.ProposerIndex}
+	s.headers[key] = append(s.headers[key], header)
+	return nil
+}
+
+// BlockHeaders returns every header previously saved for slot and proposerIndex.
+func (s *Store) BlockHeaders(ctx context.Context, slot uint64, proposerIndex uint64) ([]*ethpb.SignedBeaconBlockHeader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := headerKey{slot: slot, proposerIndex: proposerIndex}
+	out := make([]*ethpb.SignedBeaconBlockHeader, len(s.headers[key]))
+	copy(out, s.headers[key])
+	return out, nil
+}
+
+// PruneBlockHeaders deletes every saved header proposed at a slot more than pruningEpochDistance
+// epochs before currentEpoch.
+func (s *Store) PruneBlockHeaders(ctx context.Context, currentEpoch uint64, pruningEpochDistance uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if currentEpoch <= pruningEpochDistance {
+		return nil
+	}
+	cutoffSlot := (currentEpoch - pruningEpochDistance) * params.BeaconConfig().SlotsPerEpoch
+	for key := range s.headers {
+		if key.slot < cutoffSlot {
+			delete(s.headers, key)
+		}
+	}
+	return nil
+}
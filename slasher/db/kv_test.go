@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func TestStore_SaveAndRetrieveIndexedAttestation(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	att := &ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}}
+	if err := store.SaveIndexedAttestation(ctx, att); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.IndexedAttestationsForValidator(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != att {
+		t.Errorf("expected to retrieve the saved attestation for validator 1, got %v", got)
+	}
+
+	got, err = store.IndexedAttestationsForValidator(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no attestations for validator 3, got %v", got)
+	}
+}
+
+func TestStore_SaveAndRetrieveBlockHeaders(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	header := &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 5, ProposerIndex: 7}}
+	if err := store.SaveBlockHeader(ctx, header); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.BlockHeaders(ctx, 5, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != header {
+		t.Errorf("expected to retrieve the saved header for slot 5 proposer 7, got %v", got)
+	}
+
+	got, err = store.BlockHeaders(ctx, 5, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no headers for a different proposer, got %v", got)
+	}
+}
+
+func TestStore_PruneBlockHeaders(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	old := &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 1}}
+	recent := &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1000000, ProposerIndex: 2}}
+	if err := store.SaveBlockHeader(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveBlockHeader(ctx, recent); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.PruneBlockHeaders(ctx, 100000, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.BlockHeaders(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected the old header to be pruned, got %v", got)
+	}
+
+	got, err = store.BlockHeaders(ctx, 1000000, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("expected the recent header to survive pruning, got %v", got)
+	}
+}
@@ -0,0 +1,193 @@
+// Package detection implements attester and proposer slashing detection by comparing newly
+// submitted attestations and block headers against what has already been seen for the same
+// validators, and fans out every detected slashing to subscribers of the HighlySlashableEvents
+// RPC.
+package detection
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+	log "github.com/sirupsen/logrus"
+)
+
+// subscriberBufferSize bounds how many undelivered SlashingEvents a subscriber channel holds
+// before broadcastSlashingEvent starts dropping the oldest buffered event for that subscriber, so
+// one slow watchtower client can't block delivery to every other subscriber.
+const subscriberBufferSize = 64
+
+// span tracks the highest attestation target epoch seen for a validator.
+type span struct {
+	maxTargetEpoch uint64
+}
+
+// Service detects attester and proposer slashings against slasherDB's stored history and
+// publishes every detected slashing to subscribers registered via SubscribeSlashingEvents.
+type Service struct {
+	slasherDB db.Database
+
+	mu          sync.Mutex
+	spans       map[uint64]span
+	subscribers map[chan *slashpb.SlashingEvent]struct{}
+}
+
+// NewService returns a detection Service backed by slasherDB.
+func NewService(slasherDB db.Database) *Service {
+	return &Service{
+		slasherDB:   slasherDB,
+		spans:       make(map[uint64]span),
+		subscribers: make(map[chan *slashpb.SlashingEvent]struct{}),
+	}
+}
+
+// DetectAttesterSlashings compares att against every previously saved attestation from each of
+// its participants and returns an AttesterSlashing for every conflicting pair found. Two
+// attestations from the same validator conflict if they target the same epoch but disagree on
+// what was attested to (a double vote); detecting surround votes would additionally require the
+// full min/max-span algorithm, which UpdateSpans's current span bookkeeping doesn't yet support.
+func (s *Service) DetectAttesterSlashings(ctx context.Context, att *ethpb.IndexedAttestation) ([]*ethpb.AttesterSlashing, error) {
+	var slashings []*ethpb.AttesterSlashing
+	seen := make(map[uint64]bool, len(att.AttestingIndices))
+	reported := make(map[*ethpb.IndexedAttestation]bool)
+	for _, idx := range att.AttestingIndices {
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		prior, err := s.slasherDB.IndexedAttestationsForValidator(ctx, idx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range prior {
+			if !reported[p] && isDoubleVote(p, att) {
+				reported[p] = true
+				slashing := &ethpb.AttesterSlashing{Attestation_1: p, Attestation_2: att}
+				slashings = append(slashings, slashing)
+				s.broadcastSlashingEvent(&slashpb.SlashingEvent{
+					ValidatorIndices: att.AttestingIndices,
+					AttesterSlashing: slashing,
+				})
+			}
+		}
+	}
+	return slashings, nil
+}
+
+// isDoubleVote reports whether a and b are two different votes for the same target epoch.
+func isDoubleVote(a, b *ethpb.IndexedAttestation) bool {
+	if a.Data.Target.Epoch != b.Data.Target.Epoch {
+		return false
+	}
+	return !reflect.DeepEqual(a.Data, b.Data)
+}
+
+// UpdateSpans records att's target epoch against every one of its participants, extending the
+// highest target epoch tracked for that validator.
+func (s *Service) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, idx := range att.AttestingIndices {
+		sp := s.spans[idx]
+		if att.Data.Target.Epoch > sp.maxTargetEpoch {
+			sp.maxTargetEpoch = att.Data.Target.Epoch
+		}
+		s.spans[idx] = sp
+	}
+	return nil
+}
+
+// DetectProposerSlashings compares header against existing, the headers already saved for the
+// same slot and proposer, and returns a ProposerSlashing for every one that proposes a different
+// block (a double proposal).
+func (s *Service) DetectProposerSlashings(ctx context.Context, header *ethpb.SignedBeaconBlockHeader, existing []*ethpb.SignedBeaconBlockHeader) ([]*ethpb.ProposerSlashing, error) {
+	var slashings []*ethpb.ProposerSlashing
+	for _, e := range existing {
+		if sameProposal(e.Header, header.Header) {
+			continue
+		}
+		slashing := &ethpb.ProposerSlashing{Header_1: e, Header_2: header}
+		slashings = append(slashings, slashing)
+		s.broadcastSlashingEvent(&slashpb.SlashingEvent{
+			ValidatorIndices: []uint64{header.Header.ProposerIndex},
+			ProposerSlashing: slashing,
+		})
+	}
+	return slashings, nil
+}
+
+// sameProposal reports whether a and b are the same block proposal, comparing every root on the
+// header rather than just the body root. A proposer that resigns the same body with a different
+// parent or state root is still a double proposal under the protocol, even though the two
+// headers would compare equal on body root alone.
+func sameProposal(a, b *ethpb.BeaconBlockHeader) bool {
+	return bytes.Equal(a.ParentRoot, b.ParentRoot) &&
+		bytes.Equal(a.StateRoot, b.StateRoot) &&
+		bytes.Equal(a.BodyRoot, b.BodyRoot)
+}
+
+// PruneProposerHistory deletes stored block headers more than weakSubjectivityEpochs before
+// currentEpoch, so slasherDB's proposer history doesn't grow unboundedly.
+func (s *Service) PruneProposerHistory(ctx context.Context, currentEpoch uint64, weakSubjectivityEpochs uint64) error {
+	return s.slasherDB.PruneBlockHeaders(ctx, currentEpoch, weakSubjectivityEpochs)
+}
+
+// RunPruningRoutine calls PruneProposerHistory every interval with the epoch produced by
+// currentEpoch, keeping slasherDB's proposer history bounded to the last weakSubjectivityEpochs
+// epochs, until ctx is done. It's meant to be launched once, in its own goroutine, by whatever
+// constructs the Service.
+func (s *Service) RunPruningRoutine(ctx context.Context, interval time.Duration, weakSubjectivityEpochs uint64, currentEpoch func() uint64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.PruneProposerHistory(ctx, currentEpoch(), weakSubjectivityEpochs); err != nil {
+				log.WithError(err).Error("Could not prune proposer history")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SubscribeSlashingEvents returns a channel that receives every slashing detected from this
+// point forward, and an unsubscribe function the caller must invoke once done reading.
+func (s *Service) SubscribeSlashingEvents() (<-chan *slashpb.SlashingEvent, func()) {
+	ch := make(chan *slashpb.SlashingEvent, subscriberBufferSize)
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+	unsubscribe := func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}
+	return ch, unsubscribe
+}
+
+// broadcastSlashingEvent delivers evt to every current subscriber, dropping the oldest buffered
+// event for a subscriber whose channel is full rather than blocking delivery to the rest.
+func (s *Service) broadcastSlashingEvent(evt *slashpb.SlashingEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- evt:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- evt:
+			default:
+			}
+		}
+	}
+}
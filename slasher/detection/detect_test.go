@@ -0,0 +1,201 @@
+package detection
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+)
+
+func TestDetectAttesterSlashings_DoubleVote(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	first := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			Target:          &ethpb.Checkpoint{Epoch: 5},
+			BeaconBlockRoot: []byte{0x01},
+		},
+	}
+	if err := store.SaveIndexedAttestation(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{2, 3},
+		Data: &ethpb.AttestationData{
+			Target:          &ethpb.Checkpoint{Epoch: 5},
+			BeaconBlockRoot: []byte{0x02},
+		},
+	}
+
+	slashings, err := svc.DetectAttesterSlashings(ctx, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Fatalf("expected 1 slashing from validator 2's double vote, got %d", len(slashings))
+	}
+	if slashings[0].Attestation_1 != first || slashings[0].Attestation_2 != second {
+		t.Errorf("unexpected slashing pair: %v", slashings[0])
+	}
+}
+
+func TestDetectAttesterSlashings_NoConflict(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	first := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data: &ethpb.AttestationData{
+			Target:          &ethpb.Checkpoint{Epoch: 5},
+			BeaconBlockRoot: []byte{0x01},
+		},
+	}
+	if err := store.SaveIndexedAttestation(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data: &ethpb.AttestationData{
+			Target:          &ethpb.Checkpoint{Epoch: 6},
+			BeaconBlockRoot: []byte{0x02},
+		},
+	}
+	slashings, err := svc.DetectAttesterSlashings(ctx, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 0 {
+		t.Errorf("expected no slashing for votes targeting different epochs, got %d", len(slashings))
+	}
+}
+
+func TestDetectProposerSlashings_DoubleProposal(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	existing := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 10, ProposerIndex: 4, BodyRoot: []byte{0x01}},
+	}
+	incoming := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 10, ProposerIndex: 4, BodyRoot: []byte{0x02}},
+	}
+
+	slashings, err := svc.DetectProposerSlashings(ctx, incoming, []*ethpb.SignedBeaconBlockHeader{existing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Fatalf("expected 1 slashing from the double proposal, got %d", len(slashings))
+	}
+	if slashings[0].Header_1 != existing || slashings[0].Header_2 != incoming {
+		t.Errorf("unexpected slashing pair: %v", slashings[0])
+	}
+}
+
+func TestDetectProposerSlashings_SameBody(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	header := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 10, ProposerIndex: 4, BodyRoot: []byte{0x01}},
+	}
+	rebroadcast := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 10, ProposerIndex: 4, BodyRoot: []byte{0x01}},
+	}
+
+	slashings, err := svc.DetectProposerSlashings(ctx, rebroadcast, []*ethpb.SignedBeaconBlockHeader{header})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 0 {
+		t.Errorf("expected no slashing for a rebroadcast of the same body, got %d", len(slashings))
+	}
+}
+
+func TestDetectProposerSlashings_SameBodyDifferentParent(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	existing := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          10,
+			ProposerIndex: 4,
+			ParentRoot:    []byte{0xaa},
+			BodyRoot:      []byte{0x01},
+		},
+	}
+	incoming := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          10,
+			ProposerIndex: 4,
+			ParentRoot:    []byte{0xbb},
+			BodyRoot:      []byte{0x01},
+		},
+	}
+
+	slashings, err := svc.DetectProposerSlashings(ctx, incoming, []*ethpb.SignedBeaconBlockHeader{existing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Fatalf("expected 1 slashing for headers sharing a body root but diverging on parent root, got %d", len(slashings))
+	}
+}
+
+func TestUpdateSpans(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(db.NewStore())
+
+	att := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data:             &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 3}},
+	}
+	if err := svc.UpdateSpans(ctx, att); err != nil {
+		t.Fatal(err)
+	}
+	if svc.spans[1].maxTargetEpoch != 3 {
+		t.Errorf("expected span to record target epoch 3, got %d", svc.spans[1].maxTargetEpoch)
+	}
+
+	older := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data:             &ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 1}},
+	}
+	if err := svc.UpdateSpans(ctx, older); err != nil {
+		t.Fatal(err)
+	}
+	if svc.spans[1].maxTargetEpoch != 3 {
+		t.Errorf("expected span to stay at the highest seen target epoch 3, got %d", svc.spans[1].maxTargetEpoch)
+	}
+}
+
+func TestPruneProposerHistory(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	header := &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 1}}
+	if err := store.SaveBlockHeader(ctx, header); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.PruneProposerHistory(ctx, 100000, 10); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.BlockHeaders(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected the old header to be pruned, got %v", got)
+	}
+}
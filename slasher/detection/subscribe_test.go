@@ -0,0 +1,82 @@
+package detection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+)
+
+// TestSubscribeSlashingEvents_FanOut spins up two independent subscribers against the same
+// Service and verifies both receive every slashing the detector produces, mirroring how two
+// HighlySlashableEvents RPC streams would fan out from a single detected slashing.
+func TestSubscribeSlashingEvents_FanOut(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	firstSubscriber, unsubscribeFirst := svc.SubscribeSlashingEvents()
+	defer unsubscribeFirst()
+	secondSubscriber, unsubscribeSecond := svc.SubscribeSlashingEvents()
+	defer unsubscribeSecond()
+
+	existing := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 10, ProposerIndex: 4, BodyRoot: []byte{0x01}},
+	}
+	incoming := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 10, ProposerIndex: 4, BodyRoot: []byte{0x02}},
+	}
+
+	slashings, err := svc.DetectProposerSlashings(ctx, incoming, []*ethpb.SignedBeaconBlockHeader{existing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slashings) != 1 {
+		t.Fatalf("expected 1 slashing, got %d", len(slashings))
+	}
+
+	for name, ch := range map[string]<-chan *slashpb.SlashingEvent{"first": firstSubscriber, "second": secondSubscriber} {
+		select {
+		case evt := <-ch:
+			if evt.ProposerSlashing != slashings[0] {
+				t.Errorf("%s subscriber: expected the broadcast event to carry the detected slashing", name)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s subscriber: expected a slashing event to be broadcast", name)
+		}
+	}
+}
+
+// TestSubscribeSlashingEvents_Unsubscribe verifies that once a subscriber unsubscribes, later
+// slashings are no longer delivered to it but still reach a subscriber that remains registered.
+func TestSubscribeSlashingEvents_Unsubscribe(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewStore()
+	svc := NewService(store)
+
+	unsubscribedChan, unsubscribe := svc.SubscribeSlashingEvents()
+	stillSubscribed, unsubscribeStill := svc.SubscribeSlashingEvents()
+	defer unsubscribeStill()
+	unsubscribe()
+
+	existing := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 20, ProposerIndex: 9, BodyRoot: []byte{0x01}},
+	}
+	incoming := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 20, ProposerIndex: 9, BodyRoot: []byte{0x02}},
+	}
+	if _, err := svc.DetectProposerSlashings(ctx, incoming, []*ethpb.SignedBeaconBlockHeader{existing}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-unsubscribedChan:
+		t.Error("expected no event on the unsubscribed channel")
+	case <-stillSubscribed:
+	case <-time.After(time.Second):
+		t.Error("expected the still-subscribed channel to receive the event")
+	}
+}
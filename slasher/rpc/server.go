@@ -67,10 +67,6 @@ func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.Indexed
 	if err != nil {
 		return nil, err
 	}
-	domain, err := helpers.Domain(fork, req.Data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, gvr)
-	if err != nil {
-		return nil, err
-	}
 
 	pkMap, err := ss.beaconClient.FindOrGetPublicKeys(ctx, indices)
 	if err != nil {
@@ -85,10 +81,19 @@ func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.Indexed
 		pubkeys = append(pubkeys, pk)
 	}
 
-	err = attestationutil.VerifyIndexedAttestation(ctx, req, pubkeys, domain)
+	errs, err := attestationutil.VerifyIndexedAttestations(
+		ctx,
+		[]*ethpb.IndexedAttestation{req},
+		[]attestationutil.AttestationPubkeys{pubkeys},
+		gvr,
+		fork,
+	)
 	if err != nil {
-		log.WithError(err).Error("Failed to verify indexed attestation signature")
-		return nil, status.Errorf(codes.Internal, "Could not verify indexed attestation signature: %v: %v", req, err)
+		return nil, err
+	}
+	if errs[0] != nil {
+		log.WithError(errs[0]).Error("Failed to verify indexed attestation signature")
+		return nil, status.Errorf(codes.Internal, "Could not verify indexed attestation signature: %v: %v", req, errs[0])
 	}
 
 	if err := ss.slasherDB.SaveIndexedAttestation(ctx, req); err != nil {
@@ -112,5 +117,104 @@ func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.Indexed
 // IsSlashableBlock returns an proposer slashing if the block submitted
 // is a double proposal.
 func (ss *Server) IsSlashableBlock(ctx context.Context, req *ethpb.SignedBeaconBlockHeader) (*slashpb.ProposerSlashingResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "detection.IsSlashableBlock")
+	defer span.End()
+	if req == nil || req.Header == nil {
+		return nil, errors.New("nil or missing block header")
+	}
+	gvr, err := ss.beaconClient.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fork, err := p2putils.Fork(helpers.SlotToEpoch(req.Header.Slot))
+	if err != nil {
+		return nil, err
+	}
+	domain, err := helpers.Domain(fork, helpers.SlotToEpoch(req.Header.Slot), params.BeaconConfig().DomainBeaconProposer, gvr)
+	if err != nil {
+		return nil, err
+	}
+	pkMap, err := ss.beaconClient.FindOrGetPublicKeys(ctx, []uint64{req.Header.ProposerIndex})
+	if err != nil {
+		return nil, err
+	}
+	pkBytes := pkMap[req.Header.ProposerIndex]
+	pubkey, err := bls.PublicKeyFromBytes(pkBytes[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not deserialize validator public key")
+	}
+	signingRoot, err := helpers.ComputeSigningRoot(req.Header, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get signing root of block header")
+	}
+	sig, err := bls.SignatureFromBytes(req.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert bytes to signature")
+	}
+	if !sig.Verify(pubkey, signingRoot[:]) {
+		return nil, status.Errorf(codes.Internal, "Could not verify proposer signature for block header: %v", req)
+	}
+
+	existing, err := ss.slasherDB.BlockHeaders(ctx, req.Header.Slot, req.Header.ProposerIndex)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve existing block headers")
+		return nil, status.Errorf(codes.Internal, "Could not retrieve existing block headers: %v", err)
+	}
+	if err := ss.slasherDB.SaveBlockHeader(ctx, req); err != nil {
+		log.WithError(err).Error("Could not save block header")
+		return nil, status.Errorf(codes.Internal, "Could not save block header: %v", err)
+	}
+
+	slashings, err := ss.detector.DetectProposerSlashings(ctx, req, existing)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not detect proposer slashings for block header: %v: %v", req, err)
+	}
+	return &slashpb.ProposerSlashingResponse{
+		ProposerSlashing: slashings,
+	}, nil
+}
+
+// HighlySlashableEvents streams every attester and proposer slashing the detector produces to
+// the subscribing client as it happens, so watchtower clients such as block proposers and
+// monitoring dashboards don't have to poll IsSlashableAttestation/IsSlashableBlock for results.
+// If the request carries a non-empty validator index filter, only slashings implicating one of
+// those indices are forwarded.
+func (ss *Server) HighlySlashableEvents(req *slashpb.SubscribeRequest, stream slashpb.Slasher_HighlySlashableEventsServer) error {
+	filter := make(map[uint64]bool, len(req.ValidatorIndices))
+	for _, idx := range req.ValidatorIndices {
+		filter[idx] = true
+	}
+
+	events, unsubscribe := ss.detector.SubscribeSlashingEvents()
+	defer unsubscribe()
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if len(filter) > 0 && !eventMatchesFilter(evt, filter) {
+				continue
+			}
+			if err := stream.Send(evt); err != nil {
+				return status.Errorf(codes.Internal, "Could not send slashing event: %v", err)
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ss.ctx.Done():
+			return status.Error(codes.Canceled, "Slasher server shutting down")
+		}
+	}
+}
+
+// eventMatchesFilter reports whether evt implicates at least one of the validator indices in
+// filter.
+func eventMatchesFilter(evt *slashpb.SlashingEvent, filter map[uint64]bool) bool {
+	for _, idx := range evt.ValidatorIndices {
+		if filter[idx] {
+			return true
+		}
+	}
+	return false
 }
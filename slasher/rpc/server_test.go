@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// IsSlashableAttestation and IsSlashableBlock's success paths sign and verify against BLS keys,
+// the genesis validators root, and the fork schedule, all served by shared/bls,
+// beacon-chain/core/helpers, shared/p2putils, and shared/params - none of which exist anywhere
+// in this checkout. Those paths aren't covered here; only the input validation that runs before
+// any of that machinery is reached, plus the HighlySlashableEvents streaming logic, which only
+// depends on detection.Service and is fully exercisable with a fake stream.
+
+func TestServer_IsSlashableAttestation_NilData(t *testing.T) {
+	ss := &Server{ctx: context.Background()}
+	if _, err := ss.IsSlashableAttestation(context.Background(), &ethpb.IndexedAttestation{}); err == nil {
+		t.Error("expected an error for an attestation with nil data")
+	}
+}
+
+func TestServer_IsSlashableBlock_NilHeader(t *testing.T) {
+	ss := &Server{ctx: context.Background()}
+	if _, err := ss.IsSlashableBlock(context.Background(), &ethpb.SignedBeaconBlockHeader{}); err == nil {
+		t.Error("expected an error for a block header request with a nil header")
+	}
+}
+
+// fakeHighlySlashableEventsStream implements slashpb.Slasher_HighlySlashableEventsServer so
+// HighlySlashableEvents can be exercised directly instead of only detection.Service's
+// subscription mechanics underneath it.
+type fakeHighlySlashableEventsStream struct {
+	ctx context.Context
+
+	mu   sync.Mutex
+	sent []*slashpb.SlashingEvent
+}
+
+func (f *fakeHighlySlashableEventsStream) Send(evt *slashpb.SlashingEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, evt)
+	return nil
+}
+
+func (f *fakeHighlySlashableEventsStream) received() []*slashpb.SlashingEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]*slashpb.SlashingEvent, len(f.sent))
+	copy(out, f.sent)
+	return out
+}
+
+func (f *fakeHighlySlashableEventsStream) SetHeader(metadata.MD) error { return nil }
+
+func (f *fakeHighlySlashableEventsStream) SendHeader(metadata.MD) error { return nil }
+
+func (f *fakeHighlySlashableEventsStream) SetTrailer(metadata.MD) {}
+
+func (f *fakeHighlySlashableEventsStream) Context() context.Context { return f.ctx }
+
+func (f *fakeHighlySlashableEventsStream) SendMsg(m interface{}) error { return nil }
+
+func (f *fakeHighlySlashableEventsStream) RecvMsg(m interface{}) error { return nil }
+
+func newTestHeader(slot, proposerIndex uint64, bodyRoot byte) *ethpb.SignedBeaconBlockHeader {
+	return &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: slot, ProposerIndex: proposerIndex, BodyRoot: []byte{bodyRoot}},
+	}
+}
+
+func TestServer_HighlySlashableEvents_DeliversDetectedSlashing(t *testing.T) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	detector := detection.NewService(db.NewStore())
+	ss := &Server{ctx: context.Background(), detector: detector}
+	stream := &fakeHighlySlashableEventsStream{ctx: streamCtx}
+
+	done := make(chan error, 1)
+	go func() { done <- ss.HighlySlashableEvents(&slashpb.SubscribeRequest{}, stream) }()
+
+	existing := newTestHeader(10, 4, 0x01)
+	incoming := newTestHeader(10, 4, 0x02)
+	if _, err := detector.DetectProposerSlashings(context.Background(), incoming, []*ethpb.SignedBeaconBlockHeader{existing}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool { return len(stream.received()) == 1 })
+	if got := stream.received()[0].ProposerSlashing; got.Header_1 != existing || got.Header_2 != incoming {
+		t.Errorf("unexpected slashing delivered: %v", got)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected the stream to end with context.Canceled once its context is canceled, got %v", err)
+	}
+}
+
+func TestServer_HighlySlashableEvents_FiltersByValidatorIndex(t *testing.T) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	detector := detection.NewService(db.NewStore())
+	ss := &Server{ctx: context.Background(), detector: detector}
+	stream := &fakeHighlySlashableEventsStream{ctx: streamCtx}
+
+	go func() { _ = ss.HighlySlashableEvents(&slashpb.SubscribeRequest{ValidatorIndices: []uint64{99}}, stream) }()
+
+	existing := newTestHeader(10, 4, 0x01)
+	incoming := newTestHeader(10, 4, 0x02)
+	if _, err := detector.DetectProposerSlashings(context.Background(), incoming, []*ethpb.SignedBeaconBlockHeader{existing}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if len(stream.received()) != 0 {
+		t.Errorf("expected a slashing for proposer 4 to be filtered out of a subscription for validator 99, got %v", stream.received())
+	}
+
+	matchingExisting := newTestHeader(20, 99, 0x01)
+	matchingIncoming := newTestHeader(20, 99, 0x02)
+	if _, err := detector.DetectProposerSlashings(context.Background(), matchingIncoming, []*ethpb.SignedBeaconBlockHeader{matchingExisting}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(stream.received()) == 1 })
+}
+
+func TestServer_HighlySlashableEvents_StopsOnServerShutdown(t *testing.T) {
+	serverCtx, shutdown := context.WithCancel(context.Background())
+	detector := detection.NewService(db.NewStore())
+	ss := &Server{ctx: serverCtx, detector: detector}
+	stream := &fakeHighlySlashableEventsStream{ctx: context.Background()}
+
+	done := make(chan error, 1)
+	go func() { done <- ss.HighlySlashableEvents(&slashpb.SubscribeRequest{}, stream) }()
+
+	shutdown()
+	select {
+	case err := <-done:
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("expected a Canceled status on server shutdown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected HighlySlashableEvents to return once the server context was canceled")
+	}
+}
+
+// waitFor polls cond until it is true or a second elapses, failing the test if it never is.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition never became true")
+}